@@ -11,8 +11,14 @@ func main() {
 	ctx := context.Background()
 
 	ootelClient := ootel.NewOotelClient(
-		ootel.WithMetricConfig(ootel.NewMetricConfig(true, 8081)),
-		ootel.WithTraceConfig(ootel.NewTraceConfig(true, 1.0, "example-service", "1.0.0")),
+		ootel.WithMetricConfig(ootel.NewMetricConfig(true, []*ootel.ExporterConfig{
+			ootel.NewExporterConfig("prometheus", ootel.ExporterTypePrometheus, ootel.WithExporterServerPort(8081)),
+		}, ootel.WithRuntimeMetrics(15*time.Second), ootel.WithHostMetrics())),
+		ootel.WithTraceConfig(ootel.NewTraceConfig(true, 1.0, "example-service", "1.0.0", []*ootel.ExporterConfig{
+			// Assumes a local, non-TLS collector (e.g. `docker run otel/opentelemetry-collector`).
+			// OTLP exporters default to requiring TLS; opt out explicitly for local development.
+			ootel.NewExporterConfig("otlp", ootel.ExporterTypeOTLPGRPC, ootel.WithExporterInsecure(true)),
+		})),
 	)
 
 	shutdown, err := ootelClient.Init(ctx)
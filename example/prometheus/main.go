@@ -11,8 +11,21 @@ func main() {
 	ctx := context.Background()
 
 	ootelClient := ootel.NewOotelClient(
-		ootel.WithMetricConfig(ootel.NewMetricConfig(true, ootel.ExporterTypePrometheus, 8081)),
-		ootel.WithTraceConfig(ootel.NewTraceConfig(true, 1.0, "example-service", "1.0.0")),
+		ootel.WithMetricConfig(ootel.NewMetricConfig(true, []*ootel.ExporterConfig{
+			ootel.NewExporterConfig("prometheus", ootel.ExporterTypePrometheus, ootel.WithExporterServerPort(8081)),
+			ootel.NewExporterConfig("otlp", ootel.ExporterTypeOTLPGRPC),
+		}, ootel.WithRuntimeMetrics(15*time.Second), ootel.WithHostMetrics())),
+		ootel.WithTraceConfig(ootel.NewTraceConfig(true, 1.0, "example-service", "1.0.0", []*ootel.ExporterConfig{
+			// Assumes a local, non-TLS collector (e.g. `docker run otel/opentelemetry-collector`).
+			// OTLP exporters default to requiring TLS; opt out explicitly for local development.
+			ootel.NewExporterConfig("otlp-grpc", ootel.ExporterTypeOTLPGRPC, ootel.WithExporterInsecure(true)),
+			ootel.NewExporterConfig("otlp-http", ootel.ExporterTypeOTLPHTTP, ootel.WithExporterInsecure(true)),
+		},
+			// Cap root span production at 100/s (bursting to 200); child spans
+			// follow their parent's decision. This replaces the default ratio
+			// sampler, so the 1.0 sample rate above is not used.
+			ootel.WithSampler(ootel.ParentBasedSampler(ootel.RateLimitingSampler(100, 200))),
+		)),
 	)
 
 	shutdown, err := ootelClient.Init(ctx)
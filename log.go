@@ -0,0 +1,157 @@
+package ootel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc/credentials"
+)
+
+type logConfig struct {
+	Enabled        bool
+	ServiceName    string
+	ServiceVersion string
+	Exporters      []*ExporterConfig
+}
+
+func NewLogConfig(enabled bool, serviceName, serviceVersion string, exporters ...*ExporterConfig) *logConfig {
+	return &logConfig{
+		Enabled:        enabled,
+		ServiceName:    serviceName,
+		ServiceVersion: serviceVersion,
+		Exporters:      exporters,
+	}
+}
+
+func WithLogConfig(lc *logConfig) OotelClientOption {
+	return func(oc *OotelClient) {
+		oc.logConfig = lc
+	}
+}
+
+// NewLogger returns an *slog.Logger backed by the OTel logger provider
+// registered by Init, so records emitted through it are correlated to the
+// active span via TraceID/SpanID. Init must be called (with a LogConfig)
+// before records logged through it are exported.
+func NewLogger(name string) *slog.Logger {
+	return otelslog.NewLogger(name)
+}
+
+func setupLoggerProvider(ctx context.Context, lc *logConfig) (*sdklog.LoggerProvider, error) {
+	if len(lc.Exporters) == 0 {
+		return nil, fmt.Errorf("at least one log exporter must be configured")
+	}
+
+	logResource, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(lc.ServiceName),
+			semconv.ServiceVersion(lc.ServiceVersion),
+		))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log resource: %w", err)
+	}
+
+	providerOptions := []sdklog.LoggerProviderOption{
+		sdklog.WithResource(logResource),
+	}
+
+	for _, exporterConfig := range lc.Exporters {
+		logExporter, err := newLogExporter(ctx, exporterConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log exporter %q: %w", exporterConfig.Name, err)
+		}
+
+		providerOptions = append(providerOptions, sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)))
+	}
+
+	loggerProvider := sdklog.NewLoggerProvider(providerOptions...)
+	global.SetLoggerProvider(loggerProvider)
+
+	return loggerProvider, nil
+}
+
+func newLogExporter(ctx context.Context, exporterConfig *ExporterConfig) (sdklog.Exporter, error) {
+	switch exporterConfig.Type {
+	case ExporterTypeOTLPGRPC:
+		return otlploggrpc.New(ctx, logGRPCOptions(exporterConfig)...)
+	case ExporterTypeOTLPHTTP:
+		return otlploghttp.New(ctx, logHTTPOptions(exporterConfig)...)
+	default:
+		return nil, fmt.Errorf("unsupported log exporter type: %s", exporterConfig.Type)
+	}
+}
+
+func logGRPCOptions(exporterConfig *ExporterConfig) []otlploggrpc.Option {
+	options := make([]otlploggrpc.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlploggrpc.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlploggrpc.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlploggrpc.WithTLSCredentials(credentials.NewTLS(exporterConfig.TLSConfig)))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlploggrpc.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlploggrpc.WithCompressor("gzip"))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlploggrpc.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlploggrpc.WithRetry(otlploggrpc.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}
+
+func logHTTPOptions(exporterConfig *ExporterConfig) []otlploghttp.Option {
+	options := make([]otlploghttp.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlploghttp.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlploghttp.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlploghttp.WithTLSClientConfig(exporterConfig.TLSConfig))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlploghttp.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlploghttp.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}
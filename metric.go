@@ -0,0 +1,227 @@
+package ootel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/host"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+type metricConfig struct {
+	Enabled   bool
+	Exporters []*ExporterConfig
+
+	// RuntimeMetricsInterval, when non-zero, starts the Go runtime
+	// instrumentation (GC, heap, goroutine count, ...) with that minimum
+	// collection interval. See WithRuntimeMetrics.
+	RuntimeMetricsInterval time.Duration
+
+	// HostMetricsEnabled starts host instrumentation (CPU, memory, network)
+	// when true. See WithHostMetrics.
+	HostMetricsEnabled bool
+}
+
+type MetricConfigOption func(*metricConfig)
+
+func NewMetricConfig(enabled bool, exporters []*ExporterConfig, options ...MetricConfigOption) *metricConfig {
+	mc := &metricConfig{
+		Enabled:   enabled,
+		Exporters: exporters,
+	}
+
+	for _, option := range options {
+		option(mc)
+	}
+
+	return mc
+}
+
+// WithRuntimeMetrics enables go.opentelemetry.io/contrib/instrumentation/runtime
+// against the configured MeterProvider, reading Go GC/heap/goroutine metrics
+// no more often than interval.
+func WithRuntimeMetrics(interval time.Duration) MetricConfigOption {
+	return func(mc *metricConfig) {
+		mc.RuntimeMetricsInterval = interval
+	}
+}
+
+// WithHostMetrics enables go.opentelemetry.io/contrib/instrumentation/host
+// against the configured MeterProvider, reporting host CPU/memory/network
+// metrics.
+func WithHostMetrics() MetricConfigOption {
+	return func(mc *metricConfig) {
+		mc.HostMetricsEnabled = true
+	}
+}
+
+func setupMeterProvider(ctx context.Context, mc *metricConfig) (*metric.MeterProvider, error) {
+	if len(mc.Exporters) == 0 {
+		return nil, fmt.Errorf("at least one metric exporter must be configured")
+	}
+
+	readerOptions := make([]metric.Option, 0, len(mc.Exporters))
+	for _, exporterConfig := range mc.Exporters {
+		reader, err := newMetricReader(ctx, exporterConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metric exporter %q: %w", exporterConfig.Name, err)
+		}
+
+		readerOptions = append(readerOptions, metric.WithReader(reader))
+	}
+
+	meterProvider := metric.NewMeterProvider(readerOptions...)
+	otel.SetMeterProvider(meterProvider)
+
+	if mc.RuntimeMetricsInterval > 0 {
+		if err := runtime.Start(
+			runtime.WithMeterProvider(meterProvider),
+			runtime.WithMinimumReadMemStatsInterval(mc.RuntimeMetricsInterval),
+		); err != nil {
+			return nil, fmt.Errorf("failed to start runtime metrics: %w", err)
+		}
+	}
+
+	if mc.HostMetricsEnabled {
+		if err := host.Start(host.WithMeterProvider(meterProvider)); err != nil {
+			return nil, fmt.Errorf("failed to start host metrics: %w", err)
+		}
+	}
+
+	return meterProvider, nil
+}
+
+func newMetricReader(ctx context.Context, exporterConfig *ExporterConfig) (metric.Reader, error) {
+	switch exporterConfig.Type {
+	case ExporterTypePrometheus:
+		return prometheus.New(prometheusReaderOptions(exporterConfig.prometheusOptions())...)
+	case ExporterTypeOTLPGRPC:
+		metricExporter, err := otlpmetricgrpc.New(ctx, metricGRPCOptions(exporterConfig)...)
+		if err != nil {
+			return nil, err
+		}
+
+		return metric.NewPeriodicReader(metricExporter), nil
+	case ExporterTypeOTLPHTTP:
+		metricExporter, err := otlpmetrichttp.New(ctx, metricHTTPOptions(exporterConfig)...)
+		if err != nil {
+			return nil, err
+		}
+
+		return metric.NewPeriodicReader(metricExporter), nil
+	default:
+		return nil, fmt.Errorf("unsupported metric exporter type: %s", exporterConfig.Type)
+	}
+}
+
+// prometheusReaderOptions translates a PrometheusOptions into options for the
+// go.opentelemetry.io/otel/exporters/prometheus reader.
+func prometheusReaderOptions(po *PrometheusOptions) []prometheus.Option {
+	options := make([]prometheus.Option, 0)
+
+	if po.Registerer != nil {
+		options = append(options, prometheus.WithRegisterer(po.Registerer))
+	}
+	if po.WithoutUnits {
+		options = append(options, prometheus.WithoutUnits())
+	}
+	if po.WithoutTypeSuffix {
+		options = append(options, prometheus.WithoutTypeSuffix())
+	}
+	if po.WithoutScopeInfo {
+		options = append(options, prometheus.WithoutScopeInfo())
+	}
+	if po.WithoutTargetInfo {
+		options = append(options, prometheus.WithoutTargetInfo())
+	}
+	if len(po.ResourceAttributesAsLabels) > 0 {
+		options = append(options, prometheus.WithResourceAsConstantLabels(attribute.NewAllowKeysFilter(
+			toAttributeKeys(po.ResourceAttributesAsLabels)...,
+		)))
+	}
+
+	return options
+}
+
+func toAttributeKeys(names []string) []attribute.Key {
+	keys := make([]attribute.Key, len(names))
+	for i, name := range names {
+		keys[i] = attribute.Key(name)
+	}
+
+	return keys
+}
+
+func metricGRPCOptions(exporterConfig *ExporterConfig) []otlpmetricgrpc.Option {
+	options := make([]otlpmetricgrpc.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlpmetricgrpc.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlpmetricgrpc.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(exporterConfig.TLSConfig)))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlpmetricgrpc.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlpmetricgrpc.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}
+
+func metricHTTPOptions(exporterConfig *ExporterConfig) []otlpmetrichttp.Option {
+	options := make([]otlpmetrichttp.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlpmetrichttp.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlpmetrichttp.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlpmetrichttp.WithTLSClientConfig(exporterConfig.TLSConfig))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlpmetrichttp.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlpmetrichttp.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}
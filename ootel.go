@@ -2,22 +2,13 @@ package ootel
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
-	"alpineworks.io/ootel/healthcheck"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/exporters/prometheus"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
@@ -26,22 +17,243 @@ const (
 	ExporterTypeOTLPHTTP   = "otlphttp"
 )
 
-type traceConfig struct {
-	Enabled        bool
-	SampleRate     float64
-	ServiceName    string
-	ServiceVersion string
+// RetryConfig configures the exponential backoff retry policy used by OTLP
+// exporters when an export fails.
+type RetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
 }
 
-type metricConfig struct {
-	Enabled      bool
-	ExporterType string
-	ServerPort   int
+// PrometheusOptions holds the fields of ExporterConfig that only apply when
+// its Type is ExporterTypePrometheus, keeping them out of the OTLP trace/
+// metric/log exporters that share ExporterConfig.
+type PrometheusOptions struct {
+	// ServerPort is the port the /metrics (and /healthcheck) HTTP server is
+	// bound to.
+	ServerPort int
+
+	// Registerer, when set, is used instead of the default Prometheus
+	// registry. When it also implements prometheus.Gatherer (as
+	// *prometheus.Registry does), /metrics serves it via promhttp.HandlerFor
+	// so callers can isolate metrics per test or per subsystem; otherwise
+	// /metrics serves the default registry.
+	Registerer                 prometheus.Registerer
+	WithoutUnits               bool
+	WithoutTypeSuffix          bool
+	WithoutScopeInfo           bool
+	WithoutTargetInfo          bool
+	ResourceAttributesAsLabels []string
+
+	// ReadTimeout, WriteTimeout, and IdleTimeout configure the admin HTTP
+	// server startServer creates for this exporter. They are ignored when the
+	// client is configured with WithHTTPMux, since no server is started.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+// ExporterConfig describes a single named exporter that a trace, metric, or
+// log pipeline should fan out to. Not every field applies to every Type;
+// fields that don't apply to a given exporter type are ignored. When an OTLP
+// field (Endpoint, Headers, Insecure, TLSConfig, Compression, Timeout) is
+// left at its zero value, the underlying exporter falls back to the standard
+// OTEL_EXPORTER_OTLP_* environment variables, matching the upstream SDK.
+type ExporterConfig struct {
+	Name     string
+	Type     string
+	Endpoint string
+	Headers  map[string]string
+	Insecure bool
+
+	TLSConfig   *tls.Config
+	Compression string
+	Timeout     time.Duration
+	Retry       *RetryConfig
+
+	// Prometheus is only used when Type is ExporterTypePrometheus.
+	Prometheus *PrometheusOptions
+}
+
+// prometheusOptions returns ec.Prometheus, or the zero value if unset, so
+// callers never need a nil check.
+func (ec *ExporterConfig) prometheusOptions() *PrometheusOptions {
+	if ec.Prometheus != nil {
+		return ec.Prometheus
+	}
+
+	return &PrometheusOptions{}
+}
+
+type ExporterConfigOption func(*ExporterConfig)
+
+func NewExporterConfig(name, exporterType string, options ...ExporterConfigOption) *ExporterConfig {
+	exporterConfig := &ExporterConfig{
+		Name: name,
+		Type: exporterType,
+	}
+
+	for _, option := range options {
+		option(exporterConfig)
+	}
+
+	return exporterConfig
+}
+
+func WithExporterEndpoint(endpoint string) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Endpoint = endpoint
+	}
+}
+
+func WithExporterHeaders(headers map[string]string) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Headers = headers
+	}
+}
+
+func WithExporterTLSConfig(tlsConfig *tls.Config) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.TLSConfig = tlsConfig
+	}
+}
+
+func WithExporterCompression(compression string) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Compression = compression
+	}
+}
+
+func WithExporterTimeout(timeout time.Duration) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Timeout = timeout
+	}
+}
+
+// ensurePrometheus lazily initializes ec.Prometheus so the WithExporter*
+// Prometheus options can be applied in any order.
+func (ec *ExporterConfig) ensurePrometheus() *PrometheusOptions {
+	if ec.Prometheus == nil {
+		ec.Prometheus = &PrometheusOptions{}
+	}
+
+	return ec.Prometheus
+}
+
+func WithExporterServerPort(port int) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().ServerPort = port
+	}
+}
+
+func WithExporterInsecure(insecure bool) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Insecure = insecure
+	}
+}
+
+func WithExporterRetry(retry *RetryConfig) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.Retry = retry
+	}
+}
+
+func WithExporterRegisterer(registerer prometheus.Registerer) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().Registerer = registerer
+	}
+}
+
+func WithExporterWithoutUnits(withoutUnits bool) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().WithoutUnits = withoutUnits
+	}
+}
+
+func WithExporterWithoutTypeSuffix(withoutTypeSuffix bool) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().WithoutTypeSuffix = withoutTypeSuffix
+	}
+}
+
+func WithExporterWithoutScopeInfo(withoutScopeInfo bool) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().WithoutScopeInfo = withoutScopeInfo
+	}
+}
+
+func WithExporterWithoutTargetInfo(withoutTargetInfo bool) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().WithoutTargetInfo = withoutTargetInfo
+	}
+}
+
+func WithExporterResourceAttributesAsLabels(attributes []string) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().ResourceAttributesAsLabels = attributes
+	}
+}
+
+func WithExporterReadTimeout(timeout time.Duration) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().ReadTimeout = timeout
+	}
+}
+
+func WithExporterWriteTimeout(timeout time.Duration) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().WriteTimeout = timeout
+	}
+}
+
+func WithExporterIdleTimeout(timeout time.Duration) ExporterConfigOption {
+	return func(ec *ExporterConfig) {
+		ec.ensurePrometheus().IdleTimeout = timeout
+	}
+}
+
+func validateExporterNames(exporters []*ExporterConfig) error {
+	seen := make(map[string]struct{}, len(exporters))
+	for _, exporter := range exporters {
+		if exporter.Name == "" {
+			return errors.New("exporter name must not be empty")
+		}
+
+		if _, ok := seen[exporter.Name]; ok {
+			return fmt.Errorf("duplicate exporter name: %s", exporter.Name)
+		}
+		seen[exporter.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func countPrometheusExporters(exporters []*ExporterConfig) int {
+	count := 0
+	for _, exporter := range exporters {
+		if exporter.Type == ExporterTypePrometheus {
+			count++
+		}
+	}
+
+	return count
 }
 
 type OotelClient struct {
 	traceConfig  *traceConfig
 	metricConfig *metricConfig
+	logConfig    *logConfig
+
+	// httpMux, when set via WithHTTPMux, is used instead of a dedicated
+	// listener per Prometheus exporter, so callers that already run an admin
+	// server can register /metrics and /healthcheck into it.
+	httpMux *http.ServeMux
+
+	// serverErrorHandler, when set via WithServerErrorHandler, is called with
+	// any error returned by a Prometheus exporter's admin HTTP server instead
+	// of the default fmt.Println.
+	serverErrorHandler func(error)
 }
 
 type OotelClientOption func(*OotelClient)
@@ -57,32 +269,38 @@ func NewOotelClient(options ...OotelClientOption) *OotelClient {
 	return client
 }
 
-func NewTraceConfig(enabled bool, sampleRate float64, serviceName, serviceVersion string) *traceConfig {
-	return &traceConfig{
-		Enabled:        enabled,
-		SampleRate:     sampleRate,
-		ServiceName:    serviceName,
-		ServiceVersion: serviceVersion,
+func WithTraceConfig(tc *traceConfig) OotelClientOption {
+	return func(oc *OotelClient) {
+		oc.traceConfig = tc
 	}
 }
 
-func WithTraceConfig(tc *traceConfig) OotelClientOption {
+func WithMetricConfig(mc *metricConfig) OotelClientOption {
 	return func(oc *OotelClient) {
-		oc.traceConfig = tc
+		oc.metricConfig = mc
 	}
 }
 
-func NewMetricConfig(enabled bool, exporterType string, serverPort int) *metricConfig {
-	return &metricConfig{
-		Enabled:      enabled,
-		ExporterType: exporterType,
-		ServerPort:   serverPort,
+// WithHTTPMux registers ootel's /metrics and /healthcheck handlers onto mux
+// instead of starting a dedicated listener per Prometheus exporter. Use this
+// when the caller already runs its own admin server, so no second port is
+// bound. Init registers the handlers on mux each time it runs, and
+// http.ServeMux panics on a pattern registered twice, so a given mux can be
+// used by only one Init.
+func WithHTTPMux(mux *http.ServeMux) OotelClientOption {
+	return func(oc *OotelClient) {
+		oc.httpMux = mux
 	}
 }
 
-func WithMetricConfig(mc *metricConfig) OotelClientOption {
+// WithServerErrorHandler registers handler to receive errors from a
+// Prometheus exporter's admin HTTP server (e.g. a failure to bind its
+// ServerPort), so callers can react to listen failures instead of them being
+// printed and otherwise lost. Ignored when WithHTTPMux is used, since no
+// dedicated server is started in that case.
+func WithServerErrorHandler(handler func(error)) OotelClientOption {
 	return func(oc *OotelClient) {
-		oc.metricConfig = mc
+		oc.serverErrorHandler = handler
 	}
 }
 
@@ -101,113 +319,69 @@ func (oc *OotelClient) Init(ctx context.Context) (func(context.Context) error, e
 	}
 
 	if oc.traceConfig != nil && oc.traceConfig.Enabled {
-		// Set up propagator.
-		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-			propagation.TraceContext{},
-			propagation.Baggage{},
-		))
-
-		// Set up trace provider.
-		tracerProvider, err := traceProvider(ctx, oc.traceConfig)
+		if err := validateExporterNames(oc.traceConfig.Exporters); err != nil {
+			return nil, fmt.Errorf("invalid trace exporter configuration: %w", err)
+		}
+
+		tracerProvider, samplerShutdownFuncs, err := setupTraceProvider(ctx, oc.traceConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create trace provider: %w", err)
 		}
-		otel.SetTracerProvider(tracerProvider)
+		shutdownFuncs = append(shutdownFuncs, samplerShutdownFuncs...)
+		shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
 	}
 
 	if oc.metricConfig != nil && oc.metricConfig.Enabled {
-		// Set up meter provider.
-		meterProvider, err := meterProvider(ctx, oc.metricConfig.ExporterType)
+		if err := validateExporterNames(oc.metricConfig.Exporters); err != nil {
+			return nil, fmt.Errorf("invalid metric exporter configuration: %w", err)
+		}
+
+		meterProvider, err := setupMeterProvider(ctx, oc.metricConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create meter provider: %w", err)
 		}
 		shutdownFuncs = append(shutdownFuncs, meterProvider.Shutdown)
-		otel.SetMeterProvider(meterProvider)
-
-		go func() {
-			if err := startServer(oc.metricConfig.ServerPort, oc.metricConfig.ExporterType == "prometheus"); err != nil {
-				fmt.Println(err)
-			}
-		}()
-	}
 
-	return shutdown, nil
-}
-
-func traceProvider(ctx context.Context, tc *traceConfig) (*trace.TracerProvider, error) {
-	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
-	if err != nil {
-		return nil, err
-	}
-
-	traceResource, err := resource.Merge(resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(tc.ServiceName),
-			semconv.ServiceVersion(tc.ServiceVersion),
-		))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create trace resource: %w", err)
-	}
+		if oc.httpMux != nil && countPrometheusExporters(oc.metricConfig.Exporters) > 1 {
+			return nil, fmt.Errorf("only one prometheus exporter may be registered onto a shared WithHTTPMux; give each its own listener instead")
+		}
 
-	traceProvider := trace.NewTracerProvider(
-		trace.WithSpanProcessor(trace.NewBatchSpanProcessor(traceExporter)),
-		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(tc.SampleRate))),
-		trace.WithResource(traceResource),
-	)
-	return traceProvider, nil
-}
+		for _, exporter := range oc.metricConfig.Exporters {
+			if exporter.Type != ExporterTypePrometheus {
+				continue
+			}
 
-func meterProvider(ctx context.Context, exporterType string) (*metric.MeterProvider, error) {
-	var meterProvider *metric.MeterProvider
+			if oc.httpMux != nil {
+				registerMetricsHandlers(oc.httpMux, exporter)
+				continue
+			}
 
-	switch exporterType {
-	case ExporterTypePrometheus:
-		metricExporter, err := prometheus.New()
-		if err != nil {
-			return nil, fmt.Errorf("failed to create prometheus metric exporter: %w", err)
+			server, errCh := startServer(exporter)
+			shutdownFuncs = append(shutdownFuncs, server.Shutdown)
+
+			go func() {
+				for err := range errCh {
+					if oc.serverErrorHandler != nil {
+						oc.serverErrorHandler(err)
+						continue
+					}
+					fmt.Println(err)
+				}
+			}()
 		}
+	}
 
-		meterProvider = metric.NewMeterProvider(
-			metric.WithReader(metricExporter),
-		)
-	case ExporterTypeOTLPGRPC:
-		metricExporter, err := otlpmetricgrpc.New(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create otlpgrpc metric exporter: %w", err)
+	if oc.logConfig != nil && oc.logConfig.Enabled {
+		if err := validateExporterNames(oc.logConfig.Exporters); err != nil {
+			return nil, fmt.Errorf("invalid log exporter configuration: %w", err)
 		}
 
-		meterProvider = metric.NewMeterProvider(
-			metric.WithReader(
-				metric.NewPeriodicReader(metricExporter)),
-		)
-	case ExporterTypeOTLPHTTP:
-		metricExporter, err := otlpmetrichttp.New(ctx)
+		loggerProvider, err := setupLoggerProvider(ctx, oc.logConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create otlphttp metric exporter: %w", err)
+			return nil, fmt.Errorf("failed to create logger provider: %w", err)
 		}
-
-		meterProvider = metric.NewMeterProvider(
-			metric.WithReader(
-				metric.NewPeriodicReader(metricExporter)),
-		)
-	default:
-		return nil, fmt.Errorf("unsupported metric exporter type: %s", exporterType)
-
+		shutdownFuncs = append(shutdownFuncs, loggerProvider.Shutdown)
 	}
 
-	return meterProvider, nil
-}
-
-func startServer(port int, isPrometheus bool) error {
-	http.HandleFunc("/healthcheck", healthcheck.HealthcheckHandler)
-	if isPrometheus {
-		http.Handle("/metrics", promhttp.Handler())
-	}
-	err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
-	if err != nil {
-		return fmt.Errorf("failed to start server: %w", err)
-	}
-
-	return nil
+	return shutdown, nil
 }
@@ -0,0 +1,329 @@
+package ootel
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Sampler is the decision-maker consulted for every span start. It is an
+// alias for the SDK's trace.Sampler so built-ins from go.opentelemetry.io/otel
+// and the ones below can be used interchangeably on traceConfig.Sampler.
+type Sampler = trace.Sampler
+
+func AlwaysOnSampler() Sampler {
+	return trace.AlwaysSample()
+}
+
+func AlwaysOffSampler() Sampler {
+	return trace.NeverSample()
+}
+
+func TraceIDRatioSampler(ratio float64) Sampler {
+	return trace.TraceIDRatioBased(ratio)
+}
+
+func ParentBasedSampler(root Sampler, options ...trace.ParentBasedSamplerOption) Sampler {
+	return trace.ParentBased(root, options...)
+}
+
+// startStopSampler is implemented by samplers that need a background
+// goroutine, such as RemoteSampler. setupTraceProvider starts them after
+// construction and wires their Shutdown into the client's shutdown chain.
+type startStopSampler interface {
+	Start(ctx context.Context)
+	Shutdown(ctx context.Context) error
+}
+
+// rateLimitingSampler is a token-bucket sampler that admits at most
+// spansPerSecond spans per second, with bursts up to burst.
+type rateLimitingSampler struct {
+	fillRate float64
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func RateLimitingSampler(spansPerSecond float64, burst int) Sampler {
+	return &rateLimitingSampler{
+		fillRate: spansPerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(parameters trace.SamplingParameters) trace.SamplingResult {
+	decision := trace.Drop
+	if s.allow() {
+		decision = trace.RecordAndSample
+	}
+
+	return trace.SamplingResult{Decision: decision}
+}
+
+func (s *rateLimitingSampler) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.last).Seconds() * s.fillRate
+	if s.tokens > s.burst {
+		s.tokens = s.burst
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+
+	s.tokens--
+	return true
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{spansPerSecond=%.2f,burst=%.0f}", s.fillRate, s.burst)
+}
+
+// jaegerSamplingStrategy models the subset of the Jaeger remote sampling
+// protocol response that RemoteSampler understands.
+// See https://www.jaegertracing.io/docs/latest/sampling/#remote-sampling.
+type jaegerSamplingStrategy struct {
+	ProbabilisticSampling *struct {
+		SamplingRate float64 `json:"samplingRate"`
+	} `json:"probabilisticSampling"`
+	OperationSampling *struct {
+		DefaultSamplingProbability float64 `json:"defaultSamplingProbability"`
+		PerOperationStrategies     []struct {
+			Operation             string `json:"operation"`
+			ProbabilisticSampling struct {
+				SamplingRate float64 `json:"samplingRate"`
+			} `json:"probabilisticSampling"`
+		} `json:"perOperationStrategies"`
+	} `json:"operationSampling"`
+}
+
+// remoteSampler periodically polls a Jaeger-compatible remote sampling
+// endpoint for per-service (and optionally per-operation) sampling rates,
+// using fallback until the endpoint has been polled successfully. A failed
+// poll after that leaves the last fetched rates in place.
+type remoteSampler struct {
+	serviceName     string
+	endpoint        string
+	refreshInterval time.Duration
+	fallback        Sampler
+	headers         map[string]string
+	httpClient      *http.Client
+
+	mu             sync.RWMutex
+	defaultSampler Sampler
+	perOperation   map[string]Sampler
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// RemoteSamplerOption configures the HTTP client RemoteSampler uses to poll
+// its endpoint, mirroring the Headers/TLSConfig/Insecure options available on
+// the OTLP exporters in this package.
+type RemoteSamplerOption func(*remoteSampler)
+
+// WithRemoteSamplerHeaders sets extra HTTP headers (e.g. an auth token) sent
+// with every poll request.
+func WithRemoteSamplerHeaders(headers map[string]string) RemoteSamplerOption {
+	return func(rs *remoteSampler) {
+		rs.headers = headers
+	}
+}
+
+// WithRemoteSamplerTLSConfig sets the TLS configuration used to reach
+// endpoint.
+func WithRemoteSamplerTLSConfig(tlsConfig *tls.Config) RemoteSamplerOption {
+	return func(rs *remoteSampler) {
+		insecure := rs.tlsClientConfig().InsecureSkipVerify
+		transport := rs.httpClient.Transport.(*http.Transport)
+		transport.TLSClientConfig = tlsConfig.Clone()
+		transport.TLSClientConfig.InsecureSkipVerify = transport.TLSClientConfig.InsecureSkipVerify || insecure
+	}
+}
+
+// WithRemoteSamplerInsecure skips TLS certificate verification when reaching
+// endpoint.
+func WithRemoteSamplerInsecure(insecure bool) RemoteSamplerOption {
+	return func(rs *remoteSampler) {
+		rs.tlsClientConfig().InsecureSkipVerify = insecure
+	}
+}
+
+// tlsClientConfig lazily installs an *http.Transport with a non-nil
+// TLSClientConfig on rs.httpClient, so WithRemoteSamplerTLSConfig and
+// WithRemoteSamplerInsecure can be applied in either order.
+func (rs *remoteSampler) tlsClientConfig() *tls.Config {
+	transport, ok := rs.httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = &http.Transport{}
+		rs.httpClient.Transport = transport
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	return transport.TLSClientConfig
+}
+
+// RemoteSampler polls endpoint (a Jaeger remote sampling HTTP endpoint, e.g.
+// "http://localhost:5778/sampling") every refreshInterval for serviceName's
+// sampling strategy. fallback is used until the first successful poll; a
+// later failed poll keeps the most recently fetched strategy.
+func RemoteSampler(serviceName, endpoint string, refreshInterval time.Duration, fallback Sampler, options ...RemoteSamplerOption) Sampler {
+	if fallback == nil {
+		fallback = trace.AlwaysSample()
+	}
+
+	rs := &remoteSampler{
+		serviceName:     serviceName,
+		endpoint:        endpoint,
+		refreshInterval: refreshInterval,
+		fallback:        fallback,
+		httpClient:      &http.Client{Timeout: 5 * time.Second},
+		defaultSampler:  fallback,
+		perOperation:    make(map[string]Sampler),
+		stopCh:          make(chan struct{}),
+		doneCh:          make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(rs)
+	}
+
+	return rs
+}
+
+// Start performs an initial fetch using ctx, then launches a background
+// refresh loop. The loop intentionally uses its own context (rather than
+// ctx) for every subsequent fetch: ctx is typically scoped to Init's caller
+// (e.g. a startup timeout) and would otherwise permanently freeze the
+// sampler on stale rates the moment it's canceled. The loop's lifecycle is
+// instead governed solely by stopCh/Shutdown.
+func (rs *remoteSampler) Start(ctx context.Context) {
+	rs.refresh(ctx)
+
+	go func() {
+		defer close(rs.doneCh)
+
+		backgroundCtx := context.Background()
+
+		ticker := time.NewTicker(rs.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				rs.refresh(backgroundCtx)
+			case <-rs.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (rs *remoteSampler) Shutdown(ctx context.Context) error {
+	close(rs.stopCh)
+
+	select {
+	case <-rs.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rs *remoteSampler) refresh(ctx context.Context) {
+	strategy, err := rs.fetch(ctx)
+	if err != nil {
+		return
+	}
+
+	rs.applyStrategy(strategy)
+}
+
+func (rs *remoteSampler) fetch(ctx context.Context) (*jaegerSamplingStrategy, error) {
+	reqURL, err := url.Parse(rs.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote sampler endpoint: %w", err)
+	}
+
+	query := reqURL.Query()
+	query.Set("service", rs.serviceName)
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range rs.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := rs.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote sampler endpoint returned status %d", resp.StatusCode)
+	}
+
+	var strategy jaegerSamplingStrategy
+	if err := json.NewDecoder(resp.Body).Decode(&strategy); err != nil {
+		return nil, err
+	}
+
+	return &strategy, nil
+}
+
+func (rs *remoteSampler) applyStrategy(strategy *jaegerSamplingStrategy) {
+	defaultSampler := rs.fallback
+	perOperation := make(map[string]Sampler)
+
+	switch {
+	case strategy.OperationSampling != nil:
+		defaultSampler = trace.TraceIDRatioBased(strategy.OperationSampling.DefaultSamplingProbability)
+		for _, opStrategy := range strategy.OperationSampling.PerOperationStrategies {
+			perOperation[opStrategy.Operation] = trace.TraceIDRatioBased(opStrategy.ProbabilisticSampling.SamplingRate)
+		}
+	case strategy.ProbabilisticSampling != nil:
+		defaultSampler = trace.TraceIDRatioBased(strategy.ProbabilisticSampling.SamplingRate)
+	}
+
+	rs.mu.Lock()
+	rs.defaultSampler = defaultSampler
+	rs.perOperation = perOperation
+	rs.mu.Unlock()
+}
+
+func (rs *remoteSampler) ShouldSample(parameters trace.SamplingParameters) trace.SamplingResult {
+	rs.mu.RLock()
+	sampler := rs.defaultSampler
+	if opSampler, ok := rs.perOperation[parameters.Name]; ok {
+		sampler = opSampler
+	}
+	rs.mu.RUnlock()
+
+	return sampler.ShouldSample(parameters)
+}
+
+func (rs *remoteSampler) Description() string {
+	return fmt.Sprintf("RemoteSampler{service=%s,endpoint=%s}", rs.serviceName, rs.endpoint)
+}
@@ -0,0 +1,136 @@
+package ootel
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRateLimitingSamplerAllow(t *testing.T) {
+	t.Run("admits up to burst immediately", func(t *testing.T) {
+		s := &rateLimitingSampler{
+			fillRate: 1,
+			burst:    3,
+			tokens:   3,
+			last:     time.Now(),
+		}
+
+		for i := 0; i < 3; i++ {
+			if !s.allow() {
+				t.Fatalf("expected token %d to be allowed", i)
+			}
+		}
+
+		if s.allow() {
+			t.Fatal("expected burst to be exhausted")
+		}
+	})
+
+	t.Run("refills over time but clamps to burst", func(t *testing.T) {
+		s := &rateLimitingSampler{
+			fillRate: 10,
+			burst:    2,
+			tokens:   0,
+			last:     time.Now().Add(-time.Second),
+		}
+
+		if !s.allow() {
+			t.Fatal("expected a token to be available after refill")
+		}
+		if !s.allow() {
+			t.Fatal("expected a second token to be available, clamped to burst")
+		}
+		if s.allow() {
+			t.Fatal("expected tokens to be clamped to burst, not the full 1s*fillRate refill")
+		}
+	})
+
+	t.Run("denies when no tokens have accumulated", func(t *testing.T) {
+		s := &rateLimitingSampler{
+			fillRate: 1,
+			burst:    1,
+			tokens:   0,
+			last:     time.Now(),
+		}
+
+		if s.allow() {
+			t.Fatal("expected no tokens to be available")
+		}
+	})
+}
+
+func TestRemoteSamplerApplyStrategy(t *testing.T) {
+	fallback := trace.AlwaysSample()
+
+	t.Run("falls back when strategy is empty", func(t *testing.T) {
+		rs := &remoteSampler{fallback: fallback}
+
+		rs.applyStrategy(&jaegerSamplingStrategy{})
+
+		if rs.defaultSampler != fallback {
+			t.Fatal("expected defaultSampler to be the fallback sampler")
+		}
+		if len(rs.perOperation) != 0 {
+			t.Fatal("expected no per-operation overrides")
+		}
+	})
+
+	t.Run("probabilistic sampling sets the default sampler", func(t *testing.T) {
+		rs := &remoteSampler{fallback: fallback}
+
+		rs.applyStrategy(&jaegerSamplingStrategy{
+			ProbabilisticSampling: &struct {
+				SamplingRate float64 `json:"samplingRate"`
+			}{SamplingRate: 0.5},
+		})
+
+		if rs.defaultSampler == fallback {
+			t.Fatal("expected defaultSampler to be overridden by probabilistic sampling")
+		}
+		if len(rs.perOperation) != 0 {
+			t.Fatal("expected no per-operation overrides from probabilistic sampling")
+		}
+	})
+
+	t.Run("operation sampling takes precedence and sets per-operation overrides", func(t *testing.T) {
+		rs := &remoteSampler{fallback: fallback}
+
+		rs.applyStrategy(&jaegerSamplingStrategy{
+			ProbabilisticSampling: &struct {
+				SamplingRate float64 `json:"samplingRate"`
+			}{SamplingRate: 0.9},
+			OperationSampling: &struct {
+				DefaultSamplingProbability float64 `json:"defaultSamplingProbability"`
+				PerOperationStrategies     []struct {
+					Operation             string `json:"operation"`
+					ProbabilisticSampling struct {
+						SamplingRate float64 `json:"samplingRate"`
+					} `json:"probabilisticSampling"`
+				} `json:"perOperationStrategies"`
+			}{
+				DefaultSamplingProbability: 0.1,
+				PerOperationStrategies: []struct {
+					Operation             string `json:"operation"`
+					ProbabilisticSampling struct {
+						SamplingRate float64 `json:"samplingRate"`
+					} `json:"probabilisticSampling"`
+				}{
+					{
+						Operation: "GET /health",
+						ProbabilisticSampling: struct {
+							SamplingRate float64 `json:"samplingRate"`
+						}{SamplingRate: 0},
+					},
+				},
+			},
+		})
+
+		if _, ok := rs.perOperation["GET /health"]; !ok {
+			t.Fatal("expected a per-operation override for GET /health")
+		}
+		if rs.defaultSampler == fallback {
+			t.Fatal("expected defaultSampler to be overridden by operation sampling, not the fallback")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package ootel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"alpineworks.io/ootel/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// registerMetricsHandlers wires /healthcheck and /metrics into mux for the
+// given Prometheus exporter.
+func registerMetricsHandlers(mux *http.ServeMux, exporterConfig *ExporterConfig) {
+	mux.HandleFunc("/healthcheck", healthcheck.HealthcheckHandler)
+
+	if gatherer, ok := exporterConfig.prometheusOptions().Registerer.(prometheus.Gatherer); ok {
+		mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	} else {
+		mux.Handle("/metrics", promhttp.Handler())
+	}
+}
+
+// startServer spins up a dedicated, otelhttp-instrumented admin server for
+// exporterConfig's /metrics and /healthcheck endpoints. Listen errors are
+// delivered on the returned channel, which is closed once the server stops
+// serving; the caller is expected to register server.Shutdown for graceful
+// shutdown.
+func startServer(exporterConfig *ExporterConfig) (*http.Server, <-chan error) {
+	mux := http.NewServeMux()
+	registerMetricsHandlers(mux, exporterConfig)
+
+	po := exporterConfig.prometheusOptions()
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", po.ServerPort),
+		Handler:      otelhttp.NewHandler(mux, "ootel-metrics-server"),
+		ReadTimeout:  po.ReadTimeout,
+		WriteTimeout: po.WriteTimeout,
+		IdleTimeout:  po.IdleTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("failed to start server: %w", err)
+		}
+	}()
+
+	return server, errCh
+}
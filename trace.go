@@ -0,0 +1,193 @@
+package ootel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc/credentials"
+)
+
+type traceConfig struct {
+	Enabled        bool
+	SampleRate     float64
+	ServiceName    string
+	ServiceVersion string
+	Exporters      []*ExporterConfig
+
+	// Sampler overrides the default ParentBased(TraceIDRatioBased(SampleRate))
+	// sampler. Use AlwaysOnSampler, AlwaysOffSampler, TraceIDRatioSampler,
+	// ParentBasedSampler, RateLimitingSampler, or RemoteSampler, composed as
+	// needed. Set via WithSampler. A RemoteSampler is only started (and so
+	// only polls its endpoint) when it is this Sampler itself; nested inside
+	// another sampler such as ParentBasedSampler it is never started and
+	// keeps using its fallback.
+	Sampler Sampler
+}
+
+type TraceConfigOption func(*traceConfig)
+
+func NewTraceConfig(enabled bool, sampleRate float64, serviceName, serviceVersion string, exporters []*ExporterConfig, options ...TraceConfigOption) *traceConfig {
+	tc := &traceConfig{
+		Enabled:        enabled,
+		SampleRate:     sampleRate,
+		ServiceName:    serviceName,
+		ServiceVersion: serviceVersion,
+		Exporters:      exporters,
+	}
+
+	for _, option := range options {
+		option(tc)
+	}
+
+	return tc
+}
+
+// WithSampler overrides the default ParentBased(TraceIDRatioBased(SampleRate))
+// sampler with sampler.
+func WithSampler(sampler Sampler) TraceConfigOption {
+	return func(tc *traceConfig) {
+		tc.Sampler = sampler
+	}
+}
+
+func setupTraceProvider(ctx context.Context, tc *traceConfig) (*trace.TracerProvider, []func(context.Context) error, error) {
+	if len(tc.Exporters) == 0 {
+		return nil, nil, fmt.Errorf("at least one trace exporter must be configured")
+	}
+
+	traceResource, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(tc.ServiceName),
+			semconv.ServiceVersion(tc.ServiceVersion),
+		))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	sampler := tc.Sampler
+	if sampler == nil {
+		sampler = trace.ParentBased(trace.TraceIDRatioBased(tc.SampleRate))
+	}
+
+	var shutdownFuncs []func(context.Context) error
+	if s, ok := sampler.(startStopSampler); ok {
+		s.Start(ctx)
+		shutdownFuncs = append(shutdownFuncs, s.Shutdown)
+	}
+
+	providerOptions := []trace.TracerProviderOption{
+		trace.WithSampler(sampler),
+		trace.WithResource(traceResource),
+	}
+
+	for _, exporterConfig := range tc.Exporters {
+		traceExporter, err := newTraceExporter(ctx, exporterConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create trace exporter %q: %w", exporterConfig.Name, err)
+		}
+
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(trace.NewBatchSpanProcessor(traceExporter)))
+	}
+
+	tracerProvider := trace.NewTracerProvider(providerOptions...)
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider, shutdownFuncs, nil
+}
+
+func newTraceExporter(ctx context.Context, exporterConfig *ExporterConfig) (trace.SpanExporter, error) {
+	switch exporterConfig.Type {
+	case ExporterTypeOTLPGRPC:
+		return otlptracegrpc.New(ctx, traceGRPCOptions(exporterConfig)...)
+	case ExporterTypeOTLPHTTP:
+		return otlptracehttp.New(ctx, traceHTTPOptions(exporterConfig)...)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter type: %s", exporterConfig.Type)
+	}
+}
+
+// traceGRPCOptions translates exporterConfig into otlptracegrpc options.
+//
+// Note this is a behavior change from the previous hard-coded
+// otlptracegrpc.WithInsecure(): OTLP exporters now default to requiring TLS,
+// matching otlptracegrpc's own default and the metric/log OTLP exporters in
+// this package. Set WithExporterInsecure(true) to restore the old behavior
+// for a local, non-TLS collector.
+func traceGRPCOptions(exporterConfig *ExporterConfig) []otlptracegrpc.Option {
+	options := make([]otlptracegrpc.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(exporterConfig.TLSConfig)))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlptracegrpc.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlptracegrpc.WithCompressor("gzip"))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlptracegrpc.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}
+
+func traceHTTPOptions(exporterConfig *ExporterConfig) []otlptracehttp.Option {
+	options := make([]otlptracehttp.Option, 0)
+
+	if exporterConfig.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(exporterConfig.Endpoint))
+	}
+	if exporterConfig.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if exporterConfig.TLSConfig != nil {
+		options = append(options, otlptracehttp.WithTLSClientConfig(exporterConfig.TLSConfig))
+	}
+	if len(exporterConfig.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(exporterConfig.Headers))
+	}
+	if exporterConfig.Compression == "gzip" {
+		options = append(options, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if exporterConfig.Timeout > 0 {
+		options = append(options, otlptracehttp.WithTimeout(exporterConfig.Timeout))
+	}
+	if exporterConfig.Retry != nil {
+		options = append(options, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         exporterConfig.Retry.Enabled,
+			InitialInterval: exporterConfig.Retry.InitialInterval,
+			MaxInterval:     exporterConfig.Retry.MaxInterval,
+			MaxElapsedTime:  exporterConfig.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return options
+}